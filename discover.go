@@ -0,0 +1,114 @@
+package nanoleaf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grandcat/zeroconf"
+)
+
+// nanoleafService is the mDNS service type advertised by Nanoleaf controllers
+const nanoleafService = "_nanoleafapi._tcp"
+
+// Device describes a Nanoleaf controller discovered on the local network
+type Device struct {
+	Host     string
+	Port     int
+	ID       string
+	Model    string
+	Firmware string
+}
+
+// Discover browses the local network for Nanoleaf controllers via mDNS, returning
+// whatever devices answer before timeout elapses
+func Discover(ctx context.Context, timeout time.Duration) ([]Device, error) {
+	resolver, err := zeroconf.NewResolver(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(chan *zeroconf.ServiceEntry)
+	var devices []Device
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for entry := range entries {
+			devices = append(devices, deviceFromEntry(entry))
+		}
+	}()
+
+	if err := resolver.Browse(ctx, nanoleafService, "local.", entries); err != nil {
+		return nil, err
+	}
+
+	<-ctx.Done()
+	<-done
+
+	return devices, nil
+}
+
+// deviceFromEntry extracts the host, port and TXT-record metadata nanoleaf
+// controllers advertise alongside the mDNS service
+func deviceFromEntry(entry *zeroconf.ServiceEntry) Device {
+	device := Device{
+		Host: entry.HostName,
+		Port: entry.Port,
+	}
+
+	for _, txt := range entry.Text {
+		key, value, found := strings.Cut(txt, "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "id":
+			device.ID = value
+		case "md":
+			device.Model = value
+		case "fv":
+			device.Firmware = value
+		}
+	}
+
+	return device
+}
+
+// pairRetryInterval is how often Pair polls /api/v1/new while the user holds the
+// power button
+const pairRetryInterval = time.Second
+
+// Pair requests a new auth token from the device, retrying while the user holds
+// the controller's power button (the firmware opens roughly a 30s window)
+func (n *Nanoleaf) Pair(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/new", n.url)
+
+	for {
+		resp, err := n.client.R().SetContext(ctx).Post(url)
+		if err == nil && resp.StatusCode() == http.StatusOK {
+			var token struct {
+				AuthToken string `json:"auth_token"`
+			}
+
+			if err := json.Unmarshal(resp.Body(), &token); err != nil {
+				return "", ErrParsingJSON
+			}
+
+			return token.AuthToken, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(pairRetryInterval):
+		}
+	}
+}
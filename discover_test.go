@@ -0,0 +1,28 @@
+package nanoleaf
+
+import (
+	"testing"
+
+	"github.com/grandcat/zeroconf"
+)
+
+func TestDeviceFromEntry(t *testing.T) {
+	entry := &zeroconf.ServiceEntry{}
+	entry.HostName = "nanoleaf.local."
+	entry.Port = 16021
+	entry.Text = []string{"id=AA:BB:CC:DD", "md=NL29", "fv=7.2.0", "malformed"}
+
+	device := deviceFromEntry(entry)
+
+	want := Device{
+		Host:     "nanoleaf.local.",
+		Port:     16021,
+		ID:       "AA:BB:CC:DD",
+		Model:    "NL29",
+		Firmware: "7.2.0",
+	}
+
+	if device != want {
+		t.Errorf("deviceFromEntry() = %+v, want %+v", device, want)
+	}
+}
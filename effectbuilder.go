@@ -0,0 +1,114 @@
+package nanoleaf
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Panel identifies a single physical panel by its layout id
+type Panel struct {
+	ID int
+}
+
+// builderFrame is one R,G,B,transition step queued for a panel
+type builderFrame struct {
+	red        int
+	green      int
+	blue       int
+	transition int
+}
+
+// builderPanel accumulates the frames queued for a single panel id
+type builderPanel struct {
+	id     int
+	frames []builderFrame
+}
+
+// EffectBuilder assembles a custom EffectData from per-panel frame sequences
+// without requiring callers to hand-encode the whitespace-separated animData string
+type EffectBuilder struct {
+	panels []builderPanel
+	loop   bool
+}
+
+// NewEffect starts a new custom effect
+func NewEffect() *EffectBuilder {
+	return &EffectBuilder{}
+}
+
+// Panel starts queuing frames for the given panel id. Each call adds a new panel
+// entry; calling it again with an id already used does not resume the earlier entry.
+func (b *EffectBuilder) Panel(id int) *EffectBuilder {
+	b.panels = append(b.panels, builderPanel{id: id})
+	return b
+}
+
+// Frame appends an RGB frame with the given transition time to the current panel.
+// If called before any Panel, it starts an implicit panel 0 rather than panicking.
+func (b *EffectBuilder) Frame(red, green, blue, transition int) *EffectBuilder {
+	if len(b.panels) == 0 {
+		b.Panel(0)
+	}
+
+	last := len(b.panels) - 1
+	b.panels[last].frames = append(b.panels[last].frames, builderFrame{
+		red:        red,
+		green:      green,
+		blue:       blue,
+		transition: transition,
+	})
+
+	return b
+}
+
+// Loop sets whether the effect repeats once it reaches its final frame
+func (b *EffectBuilder) Loop(loop bool) *EffectBuilder {
+	b.loop = loop
+	return b
+}
+
+// Build renders the queued panels and frames into an EffectData ready for AddEffect
+// or Display
+func (b *EffectBuilder) Build() EffectData {
+	data := fmt.Sprintf("%d", len(b.panels))
+
+	for _, panel := range b.panels {
+		data = fmt.Sprintf("%s %d %d", data, panel.id, len(panel.frames))
+
+		for _, frame := range panel.frames {
+			data = fmt.Sprintf("%s %d %d %d 0 %d", data, frame.red, frame.green, frame.blue, frame.transition)
+		}
+	}
+
+	return EffectData{
+		Type:      "custom",
+		ColorType: "RGB",
+		Data:      data,
+		Loop:      b.loop,
+	}
+}
+
+// NewStaticEffect builds a custom effect that holds a single solid color across
+// every given panel
+func NewStaticEffect(panels []Panel, c color.RGBA) EffectData {
+	builder := NewEffect()
+
+	for _, panel := range panels {
+		builder.Panel(panel.ID).Frame(int(c.R), int(c.G), int(c.B), 0)
+	}
+
+	return builder.Build()
+}
+
+// NewPaletteEffect builds an EffectData driven by one of Nanoleaf's built-in
+// plugins (e.g. flow, explode) over the given color palette
+func NewPaletteEffect(palette []PaletteColor, pluginUuid string, options []PluginOption) EffectData {
+	return EffectData{
+		Type:          "plugin",
+		ColorType:     "HSB",
+		Palette:       palette,
+		PluginType:    "color",
+		PluginUuid:    pluginUuid,
+		PluginOptions: options,
+	}
+}
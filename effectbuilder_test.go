@@ -0,0 +1,29 @@
+package nanoleaf
+
+import "testing"
+
+func TestEffectBuilderBuild(t *testing.T) {
+	data := NewEffect().
+		Panel(1).Frame(255, 0, 0, 10).
+		Panel(2).Frame(0, 255, 0, 20).Frame(0, 0, 255, 30).
+		Loop(true).
+		Build()
+
+	want := "2 1 1 255 0 0 0 10 2 2 0 255 0 0 20 0 0 255 0 30"
+	if data.Data != want {
+		t.Errorf("Data = %q, want %q", data.Data, want)
+	}
+
+	if data.Type != "custom" || data.ColorType != "RGB" || !data.Loop {
+		t.Errorf("Build() = %+v, want AnimType=custom ColorType=RGB Loop=true", data)
+	}
+}
+
+func TestEffectBuilderFrameWithoutPanel(t *testing.T) {
+	data := NewEffect().Frame(1, 2, 3, 4).Build()
+
+	want := "1 0 1 1 2 3 0 4"
+	if data.Data != want {
+		t.Errorf("Data = %q, want %q", data.Data, want)
+	}
+}
@@ -1,8 +1,10 @@
 package nanoleaf
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 )
 
@@ -53,19 +55,20 @@ func newNanoEffects(nano *Nanoleaf) *NanoEffects {
 
 // List lists all effects registered
 func (e *NanoEffects) List() ([]string, error) {
+	return e.ListContext(context.Background())
+}
+
+// ListContext is List with a caller-supplied context
+func (e *NanoEffects) ListContext(ctx context.Context) ([]string, error) {
 	url := fmt.Sprintf("%s/effectsList", e.endpoint)
-	resp, err := e.nano.client.R().Get(url)
+	resp, err := e.nano.client.R().SetContext(ctx).Get(url)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return nil, ErrUnauthorized
-	}
-
 	if resp.StatusCode() != http.StatusOK {
-		return nil, ErrUnexpectedResponse
+		return nil, newAPIError("List", http.MethodGet, url, resp, false)
 	}
 
 	var effects []string
@@ -79,23 +82,20 @@ func (e *NanoEffects) List() ([]string, error) {
 
 // Set sets given effects as active
 func (e *NanoEffects) Set(name string) error {
+	return e.SetContext(context.Background(), name)
+}
+
+// SetContext is Set with a caller-supplied context
+func (e *NanoEffects) SetContext(ctx context.Context, name string) error {
 	body := jsonPayload{"select": name}
-	resp, err := e.nano.client.R().SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
+	resp, err := e.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
 
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return ErrUnauthorized
-	}
-
-	if resp.StatusCode() == http.StatusNotFound {
-		return ErrEffectNotFound
-	}
-
 	if resp.StatusCode() != http.StatusNoContent {
-		return ErrUnexpectedResponse
+		return newAPIError("Set", http.MethodPut, e.endpoint, resp, true)
 	}
 
 	return nil
@@ -103,19 +103,20 @@ func (e *NanoEffects) Set(name string) error {
 
 // Get returns the currently active effect
 func (e *NanoEffects) Get() (string, error) {
+	return e.GetContext(context.Background())
+}
+
+// GetContext is Get with a caller-supplied context
+func (e *NanoEffects) GetContext(ctx context.Context) (string, error) {
 	url := fmt.Sprintf("%s/select", e.endpoint)
-	resp, err := e.nano.client.R().Get(url)
+	resp, err := e.nano.client.R().SetContext(ctx).Get(url)
 
 	if err != nil {
 		return "", err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return "", ErrUnauthorized
-	}
-
 	if resp.StatusCode() != http.StatusOK {
-		return "", ErrUnexpectedResponse
+		return "", newAPIError("Get", http.MethodGet, url, resp, false)
 	}
 
 	var effect string
@@ -129,6 +130,11 @@ func (e *NanoEffects) Get() (string, error) {
 
 // GetEffectData returns data of the given effect
 func (e *NanoEffects) GetEffectData(effect string) (EffectData, error) {
+	return e.GetEffectDataContext(context.Background(), effect)
+}
+
+// GetEffectDataContext is GetEffectData with a caller-supplied context
+func (e *NanoEffects) GetEffectDataContext(ctx context.Context, effect string) (EffectData, error) {
 	var data EffectData
 	body := jsonPayload{
 		"write": jsonPayload{
@@ -136,26 +142,17 @@ func (e *NanoEffects) GetEffectData(effect string) (EffectData, error) {
 			"animName": effect,
 		},
 	}
-	resp, err := e.nano.client.R().SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
+	resp, err := e.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
 
 	if err != nil {
 		return data, err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return data, ErrUnauthorized
-	}
-
-	if resp.StatusCode() == http.StatusNotFound {
-		return data, ErrEffectNotFound
-	}
-
 	if resp.StatusCode() != http.StatusOK {
-		return data, ErrUnexpectedResponse
+		return data, newAPIError("GetEffectData", http.MethodPut, e.endpoint, resp, true)
 	}
 
 	if err := json.Unmarshal(resp.Body(), &data); err != nil {
-		fmt.Println(err)
 		return data, ErrParsingJSON
 	}
 
@@ -164,6 +161,11 @@ func (e *NanoEffects) GetEffectData(effect string) (EffectData, error) {
 
 // returns data of all effects on the device
 func (e *NanoEffects) GetAllEffectData() ([]EffectData, error) {
+	return e.GetAllEffectDataContext(context.Background())
+}
+
+// GetAllEffectDataContext is GetAllEffectData with a caller-supplied context
+func (e *NanoEffects) GetAllEffectDataContext(ctx context.Context) ([]EffectData, error) {
 
 	animations := struct {
 		Animations []EffectData `json:"animations"`
@@ -173,26 +175,17 @@ func (e *NanoEffects) GetAllEffectData() ([]EffectData, error) {
 			"command": "requestAll",
 		},
 	}
-	resp, err := e.nano.client.R().SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
+	resp, err := e.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
 
 	if err != nil {
 		return animations.Animations, err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return animations.Animations, ErrUnauthorized
-	}
-
-	if resp.StatusCode() == http.StatusNotFound {
-		return animations.Animations, ErrEffectNotFound
-	}
-
 	if resp.StatusCode() != http.StatusOK {
-		return animations.Animations, ErrUnexpectedResponse
+		return animations.Animations, newAPIError("GetAllEffectData", http.MethodPut, e.endpoint, resp, true)
 	}
 
 	if err := json.Unmarshal(resp.Body(), &animations); err != nil {
-		fmt.Println(err)
 		return animations.Animations, ErrParsingJSON
 	}
 
@@ -201,6 +194,11 @@ func (e *NanoEffects) GetAllEffectData() ([]EffectData, error) {
 
 // /effects (rename)
 func (e *NanoEffects) RenameEffect(animName, newName string) error {
+	return e.RenameEffectContext(context.Background(), animName, newName)
+}
+
+// RenameEffectContext is RenameEffect with a caller-supplied context
+func (e *NanoEffects) RenameEffectContext(ctx context.Context, animName, newName string) error {
 
 	body := jsonPayload{
 		"write": jsonPayload{
@@ -209,22 +207,14 @@ func (e *NanoEffects) RenameEffect(animName, newName string) error {
 			"newName":  newName,
 		},
 	}
-	resp, err := e.nano.client.R().SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
+	resp, err := e.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
 
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return ErrUnauthorized
-	}
-
-	if resp.StatusCode() == http.StatusNotFound {
-		return ErrEffectNotFound
-	}
-
 	if resp.StatusCode() != http.StatusNoContent {
-		return ErrUnexpectedResponse
+		return newAPIError("RenameEffect", http.MethodPut, e.endpoint, resp, true)
 	}
 
 	return nil
@@ -232,28 +222,25 @@ func (e *NanoEffects) RenameEffect(animName, newName string) error {
 
 // /effects (add/update)
 func (e *NanoEffects) AddEffect(data EffectData, animName string) error {
+	return e.AddEffectContext(context.Background(), data, animName)
+}
+
+// AddEffectContext is AddEffect with a caller-supplied context
+func (e *NanoEffects) AddEffectContext(ctx context.Context, data EffectData, animName string) error {
 
 	data.Command = "add"
 	data.Name = animName
 	body := jsonPayload{
 		"write": data,
 	}
-	resp, err := e.nano.client.R().SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
+	resp, err := e.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
 
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return ErrUnauthorized
-	}
-
-	if resp.StatusCode() == http.StatusNotFound {
-		return ErrEffectNotFound
-	}
-
 	if resp.StatusCode() != http.StatusNoContent {
-		return ErrUnexpectedResponse
+		return newAPIError("AddEffect", http.MethodPut, e.endpoint, resp, true)
 	}
 
 	return nil
@@ -261,6 +248,11 @@ func (e *NanoEffects) AddEffect(data EffectData, animName string) error {
 
 // deletes the given effect
 func (e *NanoEffects) DeleteEffect(animName string) error {
+	return e.DeleteEffectContext(context.Background(), animName)
+}
+
+// DeleteEffectContext is DeleteEffect with a caller-supplied context
+func (e *NanoEffects) DeleteEffectContext(ctx context.Context, animName string) error {
 
 	body := jsonPayload{
 		"write": jsonPayload{
@@ -268,22 +260,14 @@ func (e *NanoEffects) DeleteEffect(animName string) error {
 			"animName": animName,
 		},
 	}
-	resp, err := e.nano.client.R().SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
+	resp, err := e.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
 
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return ErrUnauthorized
-	}
-
-	if resp.StatusCode() == http.StatusNotFound {
-		return ErrEffectNotFound
-	}
-
 	if resp.StatusCode() != http.StatusNoContent {
-		return ErrUnexpectedResponse
+		return newAPIError("DeleteEffect", http.MethodPut, e.endpoint, resp, true)
 	}
 
 	return nil
@@ -291,18 +275,19 @@ func (e *NanoEffects) DeleteEffect(animName string) error {
 
 // WriteRaw writes the raw command (outcome will depend on your body because the nanoleaf api is not well designed)
 func (e *NanoEffects) WriteRaw(body jsonPayload) error {
-	resp, err := e.nano.client.R().SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
+	return e.WriteRawContext(context.Background(), body)
+}
+
+// WriteRawContext is WriteRaw with a caller-supplied context
+func (e *NanoEffects) WriteRawContext(ctx context.Context, body jsonPayload) error {
+	resp, err := e.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(e.endpoint)
 
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode() == http.StatusUnauthorized {
-		return ErrUnauthorized
-	}
-
 	if resp.StatusCode() != http.StatusNoContent {
-		return ErrUnexpectedResponse
+		return newAPIError("WriteRaw", http.MethodPut, e.endpoint, resp, false)
 	}
 
 	return nil
@@ -310,6 +295,11 @@ func (e *NanoEffects) WriteRaw(body jsonPayload) error {
 
 // displays effect with the given animData, looping if specified
 func (e *NanoEffects) Display(data string, loop bool) error {
+	return e.DisplayContext(context.Background(), data, loop)
+}
+
+// DisplayContext is Display with a caller-supplied context
+func (e *NanoEffects) DisplayContext(ctx context.Context, data string, loop bool) error {
 	body := jsonPayload{
 		"write": jsonPayload{
 			"command":   "display",
@@ -320,11 +310,16 @@ func (e *NanoEffects) Display(data string, loop bool) error {
 		},
 	}
 
-	return e.WriteRaw(body)
+	return e.WriteRawContext(ctx, body)
 }
 
 // temporarily displays the given effect for the specified duration
 func (e *NanoEffects) DisplayTemp(animName string, duration int) error {
+	return e.DisplayTempContext(context.Background(), animName, duration)
+}
+
+// DisplayTempContext is DisplayTemp with a caller-supplied context
+func (e *NanoEffects) DisplayTempContext(ctx context.Context, animName string, duration int) error {
 	body := jsonPayload{
 		"write": jsonPayload{
 			"command":  "displayTemp",
@@ -333,7 +328,61 @@ func (e *NanoEffects) DisplayTemp(animName string, duration int) error {
 		},
 	}
 
-	return e.WriteRaw(body)
+	return e.WriteRawContext(ctx, body)
+}
+
+// Export fetches the named effect and encodes it in the Nanoleaf scene-exchange
+// JSON format used by the mobile app and community sharing sites
+func (e *NanoEffects) Export(name string) ([]byte, error) {
+	data, err := e.GetEffectData(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(data)
+}
+
+// Import decodes a Nanoleaf scene-exchange JSON document (as produced by Export,
+// the mobile app, or a community sharing site) back into an EffectData
+func Import(data []byte) (EffectData, error) {
+	var effect EffectData
+
+	if err := json.Unmarshal(data, &effect); err != nil {
+		return effect, ErrParsingJSON
+	}
+
+	return effect, nil
+}
+
+// InstallFromURL fetches a shared scene file over HTTP, validates it decodes to
+// an effect with a name, and uploads it via AddEffect
+func (e *NanoEffects) InstallFromURL(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	effect, err := Import(body)
+	if err != nil {
+		return err
+	}
+
+	if effect.Name == "" {
+		return ErrParsingJSON
+	}
+
+	return e.AddEffectContext(ctx, effect, effect.Name)
 }
 
 // ToString returns the effect as a string
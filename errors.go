@@ -0,0 +1,114 @@
+package nanoleaf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Sentinel errors kept for backwards compatibility: callers that already do
+// errors.Is(err, ErrUnauthorized) keep working even though the error returned
+// from the client is now an *APIError wrapping one of these.
+var (
+	ErrUnauthorized       = errors.New("nanoleaf: unauthorized")
+	ErrEffectNotFound     = errors.New("nanoleaf: effect not found")
+	ErrUnexpectedResponse = errors.New("nanoleaf: unexpected response")
+	ErrParsingJSON        = errors.New("nanoleaf: failed to parse response body")
+	ErrNoFrames           = errors.New("nanoleaf: panel has no frames to stream")
+	ErrTooManyFrames      = errors.New("nanoleaf: extControl carries one frame per panel per packet, panel has more than one queued")
+)
+
+// APIError is returned for any non-success response from the device, carrying
+// enough detail (status code, endpoint, method, raw body) for callers to log or
+// act on instead of matching a flat sentinel
+type APIError struct {
+	StatusCode int
+	Endpoint   string
+	Method     string
+	Body       []byte
+	Op         string
+
+	sentinel error
+}
+
+// newAPIError builds an APIError for a response, classifying it against the
+// known sentinels so errors.Is keeps matching for existing callers. effectNotFound
+// should be true only for operations where a 404 actually means "no such effect"
+// (Set, GetEffectData, GetAllEffectData, RenameEffect, AddEffect, DeleteEffect) —
+// for ops like List/Get/WriteRaw that don't address a named effect, a 404 is just
+// an unexpected response, matching the baseline behavior this replaces.
+func newAPIError(op, method, endpoint string, resp *resty.Response, effectNotFound bool) *APIError {
+	err := &APIError{
+		StatusCode: resp.StatusCode(),
+		Endpoint:   endpoint,
+		Method:     method,
+		Body:       resp.Body(),
+		Op:         op,
+	}
+
+	switch {
+	case resp.StatusCode() == http.StatusUnauthorized:
+		err.sentinel = ErrUnauthorized
+	case resp.StatusCode() == http.StatusNotFound && effectNotFound:
+		err.sentinel = ErrEffectNotFound
+	default:
+		err.sentinel = ErrUnexpectedResponse
+	}
+
+	return err
+}
+
+// newAPIErrorFromStdlib builds an APIError for a response obtained via net/http
+// (rather than resty), such as the SSE stream's long-lived GET; see newAPIError
+// for the effectNotFound classification rule, which doesn't apply here since a
+// stream subscribe never 404s for a named effect.
+func newAPIErrorFromStdlib(op, method, endpoint string, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+
+	err := &APIError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   endpoint,
+		Method:     method,
+		Body:       body,
+		Op:         op,
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		err.sentinel = ErrUnauthorized
+	} else {
+		err.sentinel = ErrUnexpectedResponse
+	}
+
+	return err
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("nanoleaf: %s %s %s: status %d: %s", e.Method, e.Endpoint, e.Op, e.StatusCode, e.Body)
+}
+
+// Is reports whether target is the sentinel this APIError was classified as,
+// so existing errors.Is(err, ErrUnauthorized) style checks keep working
+func (e *APIError) Is(target error) bool {
+	return e.sentinel == target
+}
+
+// Retryable reports whether the request that produced this error is safe to retry
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// configureRetry enables resty's built-in retry with exponential backoff for
+// rate-limited and server-error responses
+func configureRetry(client *resty.Client) {
+	client.
+		SetRetryCount(3).
+		SetRetryWaitTime(500 * time.Millisecond).
+		SetRetryMaxWaitTime(5 * time.Second).
+		AddRetryCondition(func(resp *resty.Response, err error) bool {
+			return resp.StatusCode() == http.StatusTooManyRequests || resp.StatusCode() >= http.StatusInternalServerError
+		})
+}
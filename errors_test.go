@@ -0,0 +1,64 @@
+package nanoleaf
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func newTestResponse(statusCode int) *resty.Response {
+	return &resty.Response{
+		Request: &resty.Request{},
+		RawResponse: &http.Response{
+			StatusCode: statusCode,
+			Body:       http.NoBody,
+		},
+	}
+}
+
+func TestNewAPIErrorClassification(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		effectNotFound bool
+		want           error
+	}{
+		{"unauthorized", http.StatusUnauthorized, false, ErrUnauthorized},
+		{"unauthorized takes priority over effectNotFound", http.StatusUnauthorized, true, ErrUnauthorized},
+		{"404 on an effect op", http.StatusNotFound, true, ErrEffectNotFound},
+		{"404 on a non-effect op", http.StatusNotFound, false, ErrUnexpectedResponse},
+		{"other status", http.StatusInternalServerError, true, ErrUnexpectedResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAPIError("Op", http.MethodGet, "http://device/api/v1/op", newTestResponse(tt.statusCode), tt.effectNotFound)
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("newAPIError(status=%d, effectNotFound=%v) = %v, want match for %v", tt.statusCode, tt.effectNotFound, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorRetryable(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       bool
+	}{
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+		{http.StatusOK, false},
+		{http.StatusNotFound, false},
+	}
+
+	for _, tt := range tests {
+		err := &APIError{StatusCode: tt.statusCode}
+		if got := err.Retryable(); got != tt.want {
+			t.Errorf("APIError{StatusCode: %d}.Retryable() = %v, want %v", tt.statusCode, got, tt.want)
+		}
+	}
+}
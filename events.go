@@ -0,0 +1,242 @@
+package nanoleaf
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// eventReconnectBackoff is the initial delay before NanoEvents retries a dropped
+// /events stream; it doubles on each consecutive failure up to eventMaxBackoff
+const eventReconnectBackoff = time.Second
+const eventMaxBackoff = 30 * time.Second
+
+// NanoEvents represents the Nanoleaf SSE event-listener subsystem
+type NanoEvents struct {
+	nano     *Nanoleaf
+	endpoint string
+	cancel   context.CancelFunc
+}
+
+// EventFilter selects which event ids to subscribe to on /events
+type EventFilter struct {
+	State   bool
+	Layout  bool
+	Effects bool
+	Touch   bool
+}
+
+// ids returns the comma-separated event ids requested by the firmware's /events?id= query
+func (f EventFilter) ids() string {
+	var ids []string
+
+	if f.State {
+		ids = append(ids, "1")
+	}
+	if f.Layout {
+		ids = append(ids, "2")
+	}
+	if f.Effects {
+		ids = append(ids, "3")
+	}
+	if f.Touch {
+		ids = append(ids, "4")
+	}
+
+	return strings.Join(ids, ",")
+}
+
+// Event is the envelope delivered on the channel returned by Subscribe; exactly
+// one of the typed fields is populated depending on which id the event carries
+type Event struct {
+	State   *StateEvent
+	Layout  *LayoutEvent
+	Effects *EffectsEvent
+	Touch   *TouchEvent
+}
+
+// StateEvent reports a change to on/off, brightness, hue, saturation, color
+// temperature, or color mode
+type StateEvent struct {
+	On         bool   `json:"on"`
+	Brightness int    `json:"brightness"`
+	Hue        int    `json:"hue"`
+	Saturation int    `json:"sat"`
+	ColorTemp  int    `json:"ct"`
+	ColorMode  string `json:"colorMode"`
+}
+
+// LayoutEvent reports a change to the panel layout
+type LayoutEvent struct {
+	LogicalPanelsEnabled bool `json:"logicalPanelsEnabled"`
+}
+
+// EffectsEvent reports that the active effect changed
+type EffectsEvent struct {
+	Name string `json:"animName"`
+}
+
+// TouchEvent reports a panel tap or swipe gesture
+type TouchEvent struct {
+	PanelID   int `json:"panelId"`
+	GestureID int `json:"gesture"`
+}
+
+// sseEvent is the id/data frame as parsed off the wire before being decoded
+// into a typed Event; event: lines are not parsed
+type sseEvent struct {
+	ID   int             `json:"-"`
+	Data json.RawMessage `json:"-"`
+}
+
+// newNanoEvents returns a new NanoEvents instance
+func newNanoEvents(nano *Nanoleaf) *NanoEvents {
+	return &NanoEvents{
+		nano:     nano,
+		endpoint: fmt.Sprintf("%s/%s/events", nano.url, nano.token),
+	}
+}
+
+// Events returns the NanoEvents subsystem for this device
+func (n *Nanoleaf) Events() *NanoEvents {
+	return newNanoEvents(n)
+}
+
+// Subscribe opens the /events SSE stream filtered to the requested event ids,
+// reconnecting with backoff on disconnect until ctx is cancelled or Close is called
+func (e *NanoEvents) Subscribe(ctx context.Context, filter EventFilter) (<-chan Event, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+
+	events := make(chan Event)
+	go e.listen(ctx, filter, events)
+
+	return events, nil
+}
+
+// Close ends the active subscription and stops the reconnect loop
+func (e *NanoEvents) Close() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+}
+
+// listen owns the reconnect loop: it opens the SSE stream, reads frames until the
+// connection drops or ctx is cancelled, then retries with exponential backoff
+func (e *NanoEvents) listen(ctx context.Context, filter EventFilter, out chan<- Event) {
+	defer close(out)
+
+	backoff := eventReconnectBackoff
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := e.stream(ctx, filter, out)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if err != nil {
+			backoff *= 2
+			if backoff > eventMaxBackoff {
+				backoff = eventMaxBackoff
+			}
+		} else {
+			backoff = eventReconnectBackoff
+		}
+	}
+}
+
+// stream performs a single connection attempt, blocking until it errors, the
+// server closes the connection, or ctx is cancelled
+func (e *NanoEvents) stream(ctx context.Context, filter EventFilter, out chan<- Event) error {
+	url := fmt.Sprintf("%s?id=%s", e.endpoint, filter.ids())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIErrorFromStdlib("Subscribe", http.MethodGet, url, resp)
+	}
+
+	var pending sseEvent
+	scanner := bufio.NewScanner(resp.Body)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "id:"):
+			fmt.Sscanf(strings.TrimSpace(strings.TrimPrefix(line, "id:")), "%d", &pending.ID)
+		case strings.HasPrefix(line, "data:"):
+			pending.Data = json.RawMessage(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		case line == "":
+			if pending.Data != nil {
+				if event, ok := decodeEvent(pending); ok {
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return nil
+					}
+				}
+			}
+			pending = sseEvent{}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// decodeEvent unmarshals a raw SSE frame into the typed Event matching its id
+func decodeEvent(raw sseEvent) (Event, bool) {
+	var event Event
+
+	switch raw.ID {
+	case 1:
+		var state StateEvent
+		if err := json.Unmarshal(raw.Data, &state); err != nil {
+			return event, false
+		}
+		event.State = &state
+	case 2:
+		var layout LayoutEvent
+		if err := json.Unmarshal(raw.Data, &layout); err != nil {
+			return event, false
+		}
+		event.Layout = &layout
+	case 3:
+		var effects EffectsEvent
+		if err := json.Unmarshal(raw.Data, &effects); err != nil {
+			return event, false
+		}
+		event.Effects = &effects
+	case 4:
+		var touch TouchEvent
+		if err := json.Unmarshal(raw.Data, &touch); err != nil {
+			return event, false
+		}
+		event.Touch = &touch
+	default:
+		return event, false
+	}
+
+	return event, true
+}
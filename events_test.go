@@ -0,0 +1,182 @@
+package nanoleaf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventFilterIds(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter EventFilter
+		want   string
+	}{
+		{"none", EventFilter{}, ""},
+		{"state only", EventFilter{State: true}, "1"},
+		{"all", EventFilter{State: true, Layout: true, Effects: true, Touch: true}, "1,2,3,4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.ids(); got != tt.want {
+				t.Errorf("ids() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  sseEvent
+		ok   bool
+		want Event
+	}{
+		{
+			name: "state",
+			raw:  sseEvent{ID: 1, Data: []byte(`{"on":true,"brightness":50}`)},
+			ok:   true,
+			want: Event{State: &StateEvent{On: true, Brightness: 50}},
+		},
+		{
+			name: "layout",
+			raw:  sseEvent{ID: 2, Data: []byte(`{"logicalPanelsEnabled":true}`)},
+			ok:   true,
+			want: Event{Layout: &LayoutEvent{LogicalPanelsEnabled: true}},
+		},
+		{
+			name: "effects",
+			raw:  sseEvent{ID: 3, Data: []byte(`{"animName":"Northern Lights"}`)},
+			ok:   true,
+			want: Event{Effects: &EffectsEvent{Name: "Northern Lights"}},
+		},
+		{
+			name: "touch",
+			raw:  sseEvent{ID: 4, Data: []byte(`{"panelId":12,"gesture":2}`)},
+			ok:   true,
+			want: Event{Touch: &TouchEvent{PanelID: 12, GestureID: 2}},
+		},
+		{
+			name: "unknown id",
+			raw:  sseEvent{ID: 99, Data: []byte(`{}`)},
+			ok:   false,
+		},
+		{
+			name: "malformed data",
+			raw:  sseEvent{ID: 1, Data: []byte(`not json`)},
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := decodeEvent(tt.raw)
+			if ok != tt.ok {
+				t.Fatalf("decodeEvent() ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && !reflect.DeepEqual(event, tt.want) {
+				t.Errorf("decodeEvent() = %+v, want %+v", event, tt.want)
+			}
+		})
+	}
+}
+
+// sseHandler writes a fixed slice of pre-formatted SSE frames, flushing after
+// each one so the client observes them as they arrive.
+func sseHandler(frames []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+
+		for _, frame := range frames {
+			w.Write([]byte(frame))
+			flusher.Flush()
+		}
+	}
+}
+
+func TestNanoEventsStream(t *testing.T) {
+	server := httptest.NewServer(sseHandler([]string{
+		"id:1\ndata:{\"on\":true,\"brightness\":75}\n\n",
+	}))
+	defer server.Close()
+
+	nano := &Nanoleaf{url: server.URL, token: "test-token"}
+	events := newNanoEvents(nano)
+	events.endpoint = server.URL
+
+	out := make(chan Event, 1)
+	if err := events.stream(context.Background(), EventFilter{State: true}, out); err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	select {
+	case event := <-out:
+		if event.State == nil || !event.State.On || event.State.Brightness != 75 {
+			t.Errorf("stream() delivered %+v, want State{On:true Brightness:75}", event)
+		}
+	default:
+		t.Fatal("stream() delivered no event")
+	}
+}
+
+func TestNanoEventsStreamUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	nano := &Nanoleaf{url: server.URL, token: "test-token"}
+	events := newNanoEvents(nano)
+	events.endpoint = server.URL
+
+	out := make(chan Event, 1)
+	err := events.stream(context.Background(), EventFilter{State: true}, out)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("stream() error = %v (%T), want *APIError", err, err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusUnauthorized)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("stream() error does not match ErrUnauthorized")
+	}
+}
+
+func TestNanoEventsListenAppliesBackoffOnCleanDisconnect(t *testing.T) {
+	calls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// Closes immediately with an empty body: a clean disconnect, stream() returns nil.
+	}))
+	defer server.Close()
+
+	nano := &Nanoleaf{url: server.URL, token: "test-token"}
+	events := newNanoEvents(nano)
+	events.endpoint = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	out := make(chan Event)
+	events.listen(ctx, EventFilter{State: true}, out)
+
+	// With the backoff-gated wait applied after every stream() return (not just
+	// errors), a 150ms ctx deadline against a 1s backoff floor should only allow
+	// one reconnect attempt. Without it, listen() would spin and call the server
+	// many times before ctx expires.
+	if calls < 1 {
+		t.Fatal("listen() never called the server")
+	}
+	if calls > 2 {
+		t.Errorf("listen() reconnected %d times in 150ms against a %v backoff floor, backoff wasn't applied on clean disconnect", calls, eventReconnectBackoff)
+	}
+}
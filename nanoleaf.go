@@ -0,0 +1,34 @@
+package nanoleaf
+
+import (
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// jsonPayload is the generic JSON object shape used for request and response
+// bodies across the API
+type jsonPayload map[string]interface{}
+
+// Nanoleaf is a client bound to a single Nanoleaf controller
+type Nanoleaf struct {
+	url    string
+	token  string
+	client *resty.Client
+}
+
+// New returns a client for the Nanoleaf controller reachable at host:port,
+// authenticated with token (obtained via Pair). Retry is configured once on the
+// shared client here, so every subsystem that sends through it (Effects, Stream,
+// Pair) gets the same 429/5xx retry-with-backoff behavior. Events opens its
+// stream with net/http's default client and does not use this retry.
+func New(host string, port int, token string) *Nanoleaf {
+	client := resty.New()
+	configureRetry(client)
+
+	return &Nanoleaf{
+		url:    fmt.Sprintf("http://%s:%d/api/v1", host, port),
+		token:  token,
+		client: client,
+	}
+}
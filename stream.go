@@ -0,0 +1,163 @@
+package nanoleaf
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// streamFrameInterval is the minimum spacing between frames sent to a single
+// panel, matching Nanoleaf's ~10 Hz per-panel guidance for extControl streams.
+const streamFrameInterval = 100 * time.Millisecond
+
+// NanoStream represents the Nanoleaf "extControl" UDP streaming subsystem
+type NanoStream struct {
+	nano     *Nanoleaf
+	endpoint string
+}
+
+// StreamSession is an open UDP connection to a device's extControl port
+type StreamSession struct {
+	conn     *net.UDPConn
+	lastSent time.Time
+	mu       sync.Mutex
+}
+
+// newNanoStream returns a new NanoStream instance
+func newNanoStream(nano *Nanoleaf) *NanoStream {
+	return &NanoStream{
+		nano:     nano,
+		endpoint: fmt.Sprintf("%s/%s/effects", nano.url, nano.token),
+	}
+}
+
+// Stream returns the NanoStream subsystem for this device
+func (n *Nanoleaf) Stream() *NanoStream {
+	return newNanoStream(n)
+}
+
+// StreamFrame is one RGB+transition step queued for a panel in a StreamEffect
+type StreamFrame struct {
+	Red        int
+	Green      int
+	Blue       int
+	Transition int
+}
+
+// StreamPanel is a single physical panel's queued frame in a StreamEffect
+type StreamPanel struct {
+	ID     int
+	Frames []StreamFrame
+}
+
+// StreamEffect is a per-panel set of frames. ToString encodes the full queue on
+// each StreamPanel into a custom animData string; SendFrame instead pushes the
+// current color over the extControl UDP wire, which carries exactly one frame
+// per panel per packet, so each StreamPanel must hold exactly one frame.
+type StreamEffect struct {
+	Panels []StreamPanel
+}
+
+// streamControlInfo is the response to the extControl handshake
+type streamControlInfo struct {
+	StreamControl struct {
+		Address  string `json:"streamControlIpAddr"`
+		Port     int    `json:"streamControlPort"`
+		Protocol string `json:"streamControlProtocol"`
+	} `json:"streamControl"`
+}
+
+// Start negotiates extControl v2 streaming and opens the UDP socket used to push frames
+func (s *NanoStream) Start() (*StreamSession, error) {
+	return s.StartContext(context.Background())
+}
+
+// StartContext is Start with a caller-supplied context
+func (s *NanoStream) StartContext(ctx context.Context) (*StreamSession, error) {
+	body := jsonPayload{
+		"write": jsonPayload{
+			"command":           "display",
+			"animType":          "extControl",
+			"extControlVersion": "v2",
+		},
+	}
+
+	resp, err := s.nano.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Put(s.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newAPIError("Start", http.MethodPut, s.endpoint, resp, false)
+	}
+
+	var info streamControlInfo
+	if err := json.Unmarshal(resp.Body(), &info); err != nil {
+		return nil, ErrParsingJSON
+	}
+
+	addr := fmt.Sprintf("%s:%d", info.StreamControl.Address, info.StreamControl.Port)
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.DialUDP("udp", nil, udpAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamSession{conn: conn}, nil
+}
+
+// SendFrame encodes the effect in the extControl v2 wire format and pushes it over
+// UDP, rate limited to respect the ~10 Hz per-panel guidance
+func (s *StreamSession) SendFrame(effect StreamEffect) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elapsed := time.Since(s.lastSent); elapsed < streamFrameInterval {
+		time.Sleep(streamFrameInterval - elapsed)
+	}
+
+	buf := make([]byte, 2, 2+len(effect.Panels)*8)
+	binary.BigEndian.PutUint16(buf, uint16(len(effect.Panels)))
+
+	for _, panel := range effect.Panels {
+		if len(panel.Frames) == 0 {
+			return fmt.Errorf("panel %d: %w", panel.ID, ErrNoFrames)
+		}
+		if len(panel.Frames) > 1 {
+			return fmt.Errorf("panel %d: %w", panel.ID, ErrTooManyFrames)
+		}
+		frame := panel.Frames[0]
+
+		panelBuf := make([]byte, 8)
+		binary.BigEndian.PutUint16(panelBuf[0:2], uint16(panel.ID))
+		panelBuf[2] = byte(frame.Red)
+		panelBuf[3] = byte(frame.Green)
+		panelBuf[4] = byte(frame.Blue)
+		panelBuf[5] = 0 // white
+		binary.BigEndian.PutUint16(panelBuf[6:8], uint16(frame.Transition))
+
+		buf = append(buf, panelBuf...)
+	}
+
+	if _, err := s.conn.Write(buf); err != nil {
+		return err
+	}
+
+	s.lastSent = time.Now()
+
+	return nil
+}
+
+// Close tears down the UDP socket
+func (s *StreamSession) Close() error {
+	return s.conn.Close()
+}
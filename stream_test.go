@@ -0,0 +1,136 @@
+package nanoleaf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func newLoopbackSession(t *testing.T) (*StreamSession, *net.UDPConn) {
+	t.Helper()
+
+	server, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("ListenUDP: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	conn, err := net.DialUDP("udp", nil, server.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatalf("DialUDP: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &StreamSession{conn: conn}, server
+}
+
+func TestSendFrameWireFormat(t *testing.T) {
+	session, server := newLoopbackSession(t)
+
+	effect := StreamEffect{
+		Panels: []StreamPanel{
+			{ID: 1, Frames: []StreamFrame{{Red: 10, Green: 20, Blue: 30, Transition: 5}}},
+		},
+	}
+
+	if err := session.SendFrame(effect); err != nil {
+		t.Fatalf("SendFrame: %v", err)
+	}
+
+	buf := make([]byte, 32)
+	n, err := server.Read(buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	want := []byte{0, 1, 0, 1, 10, 20, 30, 0, 0, 5}
+	if string(buf[:n]) != string(want) {
+		t.Errorf("wire frame = %v, want %v", buf[:n], want)
+	}
+}
+
+func TestSendFrameNoFramesErrors(t *testing.T) {
+	session, _ := newLoopbackSession(t)
+
+	effect := StreamEffect{Panels: []StreamPanel{{ID: 1}}}
+
+	err := session.SendFrame(effect)
+	if !errors.Is(err, ErrNoFrames) {
+		t.Errorf("SendFrame() error = %v, want ErrNoFrames", err)
+	}
+}
+
+func TestSendFrameTooManyFramesErrors(t *testing.T) {
+	session, _ := newLoopbackSession(t)
+
+	effect := StreamEffect{
+		Panels: []StreamPanel{
+			{ID: 1, Frames: []StreamFrame{
+				{Red: 1, Green: 2, Blue: 3, Transition: 1},
+				{Red: 4, Green: 5, Blue: 6, Transition: 1},
+			}},
+		},
+	}
+
+	err := session.SendFrame(effect)
+	if !errors.Is(err, ErrTooManyFrames) {
+		t.Errorf("SendFrame() error = %v, want ErrTooManyFrames", err)
+	}
+}
+
+// TestNanoleafStreamStartEndToEnd exercises the full path from Nanoleaf.Stream()
+// through the extControl HTTP handshake to a working UDP session, rather than
+// constructing a StreamSession directly.
+func TestNanoleafStreamStartEndToEnd(t *testing.T) {
+	udpServer, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("ListenUDP: %v", err)
+	}
+	t.Cleanup(func() { udpServer.Close() })
+
+	udpAddr := udpServer.LocalAddr().(*net.UDPAddr)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"streamControl":{"streamControlIpAddr":"127.0.0.1","streamControlPort":%d,"streamControlProtocol":"udp"}}`, udpAddr.Port)
+	}))
+	t.Cleanup(httpServer.Close)
+
+	nano := &Nanoleaf{url: httpServer.URL, token: "test-token", client: resty.New()}
+
+	session, err := nano.Stream().Start()
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+
+	effect := StreamEffect{
+		Panels: []StreamPanel{
+			{ID: 7, Frames: []StreamFrame{{Red: 1, Green: 2, Blue: 3, Transition: 4}}},
+		},
+	}
+
+	if err := session.SendFrame(effect); err != nil {
+		t.Fatalf("SendFrame: %v", err)
+	}
+
+	buf := make([]byte, 32)
+	n, _, err := udpServer.ReadFromUDP(buf)
+	if err != nil {
+		t.Fatalf("ReadFromUDP: %v", err)
+	}
+
+	want := []byte{0, 1, 0, 7, 1, 2, 3, 0, 0, 4}
+	if string(buf[:n]) != string(want) {
+		t.Errorf("wire frame = %v, want %v", buf[:n], want)
+	}
+}